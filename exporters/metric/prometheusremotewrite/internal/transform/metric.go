@@ -0,0 +1,376 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform provides translations for opentelemetry-go concepts and
+// structures to Prometheus remote write structures.
+package transform // import "go.opentelemetry.io/otel/exporters/metric/prometheusremotewrite/internal/transform"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+var (
+	// ErrUnimplementedAgg is returned when a transformation of an
+	// unimplemented aggregator is attempted.
+	ErrUnimplementedAgg = errors.New("unimplemented aggregator")
+
+	// ErrIncompatibleAgg is returned when aggregation.Kind implies an
+	// interface conversion that has failed.
+	ErrIncompatibleAgg = errors.New("incompatible aggregation type")
+
+	// ErrUnsupportedTemporality is returned when a Record is reported with
+	// delta temporality, which Prometheus remote write cannot represent.
+	ErrUnsupportedTemporality = errors.New("prometheusremotewrite: delta temporality is not supported, use cumulative export kind")
+
+	// ErrContextCanceled is returned when a context cancellation halts a
+	// transformation.
+	ErrContextCanceled = errors.New("context canceled")
+
+	// ErrTransforming is returned when an unexpected error is encountered
+	// transforming.
+	ErrTransforming = errors.New("transforming failed")
+
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// Option configures how Records are transformed into Prometheus time
+// series.
+type Option struct {
+	// Sanitize replaces characters that are not valid in a Prometheus
+	// metric or label name. If nil, DefaultSanitize is used.
+	Sanitize func(string) string
+
+	// ExternalLabels are attached to every series produced, in addition to
+	// the resource and record labels. They are overridden by labels of the
+	// same name found on the Record.
+	ExternalLabels map[string]string
+}
+
+// DefaultSanitize replaces any character outside of `[a-zA-Z0-9_]` with
+// `_`, which is the set of characters Prometheus allows in metric and
+// label names.
+func DefaultSanitize(name string) string {
+	return invalidNameChars.ReplaceAllString(name, "_")
+}
+
+func (o Option) sanitize(name string) string {
+	if o.Sanitize == nil {
+		return DefaultSanitize(name)
+	}
+	return o.Sanitize(name)
+}
+
+// result is the product of transforming a Record into Prometheus
+// TimeSeries.
+type result struct {
+	Series []prompb.TimeSeries
+	Err    error
+}
+
+// CheckpointSet transforms all records contained in a checkpoint into a
+// slice of Prometheus TimeSeries, suitable for embedding in a
+// prompb.WriteRequest.
+func CheckpointSet(ctx context.Context, exportSelector export.ExportKindSelector, cps export.CheckpointSet, res map[string]string, numWorkers uint, opt Option) ([]prompb.TimeSeries, error) {
+	records, errc := source(ctx, exportSelector, cps)
+
+	transformed := make(chan result)
+	var wg sync.WaitGroup
+	wg.Add(int(numWorkers))
+	for i := uint(0); i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			transformer(ctx, exportSelector, res, opt, records, transformed)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(transformed)
+	}()
+
+	series, err := sink(ctx, transformed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// source starts a goroutine that sends each one of the Records yielded by
+// the CheckpointSet on the returned chan. Any error encountered will be
+// sent on the returned error chan after seeding is complete.
+func source(ctx context.Context, exportSelector export.ExportKindSelector, cps export.CheckpointSet) (<-chan export.Record, <-chan error) {
+	errc := make(chan error, 1)
+	out := make(chan export.Record)
+	go func() {
+		defer close(out)
+		errc <- cps.ForEach(exportSelector, func(r export.Record) error {
+			select {
+			case <-ctx.Done():
+				return ErrContextCanceled
+			case out <- r:
+			}
+			return nil
+		})
+	}()
+	return out, errc
+}
+
+// transformer transforms records read from the passed in chan into
+// Prometheus TimeSeries which are sent on the out chan.
+func transformer(ctx context.Context, exportSelector export.ExportKindSelector, res map[string]string, opt Option, in <-chan export.Record, out chan<- result) {
+	for r := range in {
+		series, err := Record(exportSelector, res, opt, r)
+		if err == nil && series == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case out <- result{Series: series, Err: err}:
+		}
+	}
+}
+
+// sink collects transformed TimeSeries into a single slice.
+//
+// Any errors encountered transforming input will be reported with an
+// ErrTransforming as well as the series that did transform successfully.
+// It is up to the caller to decide whether to send partial results.
+func sink(ctx context.Context, in <-chan result) ([]prompb.TimeSeries, error) {
+	var errStrings []string
+	var series []prompb.TimeSeries
+	for res := range in {
+		if res.Err != nil {
+			errStrings = append(errStrings, res.Err.Error())
+			continue
+		}
+		series = append(series, res.Series...)
+	}
+
+	if len(errStrings) > 0 {
+		return series, fmt.Errorf("%w:\n -%s", ErrTransforming, strings.Join(errStrings, "\n -"))
+	}
+	return series, nil
+}
+
+// Record transforms a Record into zero or more Prometheus TimeSeries. An
+// ErrUnimplementedAgg error is returned if the Record's aggregation kind is
+// not supported, an ErrIncompatibleAgg error if its Aggregator does not
+// implement the interface that kind implies, and an
+// ErrUnsupportedTemporality error if a Sum or Histogram Record's export
+// kind is delta.
+func Record(exportSelector export.ExportKindSelector, res map[string]string, opt Option, r export.Record) ([]prompb.TimeSeries, error) {
+	agg := r.Aggregation()
+	switch agg.Kind() {
+	case aggregation.SumKind:
+		s, ok := agg.(aggregation.Sum)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		ek := exportSelector.ExportKindFor(r.Descriptor(), aggregation.SumKind)
+		if ek == export.DeltaExportKind {
+			return nil, ErrUnsupportedTemporality
+		}
+		sum, err := s.Sum()
+		if err != nil {
+			return nil, err
+		}
+		return counterSeries(r, res, opt, sum)
+
+	case aggregation.LastValueKind:
+		lv, ok := agg.(aggregation.LastValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		value, tm, err := lv.LastValue()
+		if err != nil {
+			return nil, err
+		}
+		return gaugeSeries(r, res, opt, value, tm)
+
+	case aggregation.HistogramKind:
+		h, ok := agg.(aggregation.Histogram)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		ek := exportSelector.ExportKindFor(r.Descriptor(), aggregation.HistogramKind)
+		if ek == export.DeltaExportKind {
+			return nil, ErrUnsupportedTemporality
+		}
+		return histogramSeries(r, res, opt, h)
+
+	case aggregation.MinMaxSumCountKind:
+		mmsc, ok := agg.(aggregation.MinMaxSumCount)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return minMaxSumCountSeries(r, res, opt, mmsc)
+
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnimplementedAgg, agg)
+	}
+}
+
+// labelsFor merges resource attributes, record labels and external labels
+// into a sorted, sanitized slice of prompb.Label, with __name__ set to
+// name.
+func labelsFor(name string, res map[string]string, r export.Record, opt Option) []prompb.Label {
+	m := make(map[string]string, len(res)+len(opt.ExternalLabels))
+	for k, v := range opt.ExternalLabels {
+		m[opt.sanitize(k)] = v
+	}
+	for k, v := range res {
+		m[opt.sanitize(k)] = v
+	}
+	iter := r.Labels().Iter()
+	for iter.Next() {
+		kv := iter.Label()
+		m[opt.sanitize(string(kv.Key))] = kv.Value.Emit()
+	}
+
+	labels := make([]prompb.Label, 0, len(m)+1)
+	labels = append(labels, prompb.Label{Name: "__name__", Value: opt.sanitize(name)})
+	for k, v := range m {
+		labels = append(labels, prompb.Label{Name: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
+
+func sample(ts time.Time, value float64) prompb.Sample {
+	return prompb.Sample{Timestamp: ts.UnixNano() / int64(time.Millisecond), Value: value}
+}
+
+func counterSeries(r export.Record, res map[string]string, opt Option, num number.Number) ([]prompb.TimeSeries, error) {
+	desc := r.Descriptor()
+	v := num.CoerceToFloat64(desc.NumberKind())
+	return []prompb.TimeSeries{
+		{
+			Labels:  labelsFor(desc.Name(), res, r, opt),
+			Samples: []prompb.Sample{sample(r.EndTime(), v)},
+		},
+	}, nil
+}
+
+func gaugeSeries(r export.Record, res map[string]string, opt Option, num number.Number, ts time.Time) ([]prompb.TimeSeries, error) {
+	desc := r.Descriptor()
+	v := num.CoerceToFloat64(desc.NumberKind())
+	return []prompb.TimeSeries{
+		{
+			Labels:  labelsFor(desc.Name(), res, r, opt),
+			Samples: []prompb.Sample{sample(ts, v)},
+		},
+	}, nil
+}
+
+func minMaxSumCountSeries(r export.Record, res map[string]string, opt Option, a aggregation.MinMaxSumCount) ([]prompb.TimeSeries, error) {
+	desc := r.Descriptor()
+	min, err := a.Min()
+	if err != nil {
+		return nil, err
+	}
+	max, err := a.Max()
+	if err != nil {
+		return nil, err
+	}
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	nk := desc.NumberKind()
+	end := r.EndTime()
+	return []prompb.TimeSeries{
+		{Labels: labelsFor(desc.Name()+"_min", res, r, opt), Samples: []prompb.Sample{sample(end, min.CoerceToFloat64(nk))}},
+		{Labels: labelsFor(desc.Name()+"_max", res, r, opt), Samples: []prompb.Sample{sample(end, max.CoerceToFloat64(nk))}},
+		{Labels: labelsFor(desc.Name()+"_sum", res, r, opt), Samples: []prompb.Sample{sample(end, sum.CoerceToFloat64(nk))}},
+		{Labels: labelsFor(desc.Name()+"_count", res, r, opt), Samples: []prompb.Sample{sample(end, float64(count))}},
+	}, nil
+}
+
+// histogramSeries transforms a Histogram Aggregator into the series
+// Prometheus expects for a histogram: one `_bucket` series per boundary
+// (plus the implicit `+Inf` bucket), and `_sum`/`_count` series.
+func histogramSeries(r export.Record, res map[string]string, opt Option, a aggregation.Histogram) ([]prompb.TimeSeries, error) {
+	desc := r.Descriptor()
+	buckets, err := a.Histogram()
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets.Counts) != len(buckets.Boundaries)+1 {
+		return nil, ErrTransforming
+	}
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	end := r.EndTime()
+	series := make([]prompb.TimeSeries, 0, len(buckets.Counts)+2)
+
+	var cumulative uint64
+	for i, boundary := range buckets.Boundaries {
+		cumulative += buckets.Counts[i]
+		labels := labelsFor(desc.Name()+"_bucket", res, r, opt)
+		labels = append(labels, prompb.Label{Name: "le", Value: formatBound(boundary)})
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+		series = append(series, prompb.TimeSeries{
+			Labels:  labels,
+			Samples: []prompb.Sample{sample(end, float64(cumulative))},
+		})
+	}
+	// The implicit +Inf bucket contains the total count.
+	infLabels := labelsFor(desc.Name()+"_bucket", res, r, opt)
+	infLabels = append(infLabels, prompb.Label{Name: "le", Value: "+Inf"})
+	sort.Slice(infLabels, func(i, j int) bool { return infLabels[i].Name < infLabels[j].Name })
+	series = append(series, prompb.TimeSeries{
+		Labels:  infLabels,
+		Samples: []prompb.Sample{sample(end, float64(count))},
+	})
+
+	nk := desc.NumberKind()
+	series = append(series,
+		prompb.TimeSeries{Labels: labelsFor(desc.Name()+"_sum", res, r, opt), Samples: []prompb.Sample{sample(end, sum.CoerceToFloat64(nk))}},
+		prompb.TimeSeries{Labels: labelsFor(desc.Name()+"_count", res, r, opt), Samples: []prompb.Sample{sample(end, float64(count))}},
+	)
+	return series, nil
+}
+
+func formatBound(b float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%f", b), "0"), ".")
+}
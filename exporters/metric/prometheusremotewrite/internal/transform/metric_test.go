@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// fakeAggregation implements the Kind() method every aggregation.* interface
+// embeds, so the fakes below only need to add the handful of accessors
+// Record actually calls.
+type fakeAggregation struct{ kind aggregation.Kind }
+
+func (f fakeAggregation) Kind() aggregation.Kind { return f.kind }
+
+type fakeSum struct {
+	fakeAggregation
+	sum number.Number
+}
+
+func (f fakeSum) Sum() (number.Number, error) { return f.sum, nil }
+
+type fakeLastValue struct {
+	fakeAggregation
+	value number.Number
+	ts    time.Time
+}
+
+func (f fakeLastValue) LastValue() (number.Number, time.Time, error) { return f.value, f.ts, nil }
+
+type fakeMinMaxSumCount struct {
+	fakeAggregation
+	min, max, sum number.Number
+	count         uint64
+}
+
+func (f fakeMinMaxSumCount) Min() (number.Number, error) { return f.min, nil }
+func (f fakeMinMaxSumCount) Max() (number.Number, error) { return f.max, nil }
+func (f fakeMinMaxSumCount) Sum() (number.Number, error) { return f.sum, nil }
+func (f fakeMinMaxSumCount) Count() (uint64, error)      { return f.count, nil }
+
+type fakeHistogram struct {
+	fakeAggregation
+	sum        number.Number
+	count      uint64
+	boundaries []float64
+	counts     []uint64
+}
+
+func (f fakeHistogram) Sum() (number.Number, error) { return f.sum, nil }
+func (f fakeHistogram) Count() (uint64, error)      { return f.count, nil }
+func (f fakeHistogram) Histogram() (aggregation.Buckets, error) {
+	return aggregation.Buckets{Boundaries: f.boundaries, Counts: f.counts}, nil
+}
+
+type cumulativeSelector struct{}
+
+func (cumulativeSelector) ExportKindFor(*export.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.CumulativeExportKind
+}
+
+type deltaSelector struct{}
+
+func (deltaSelector) ExportKindFor(*export.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.DeltaExportKind
+}
+
+func newTestRecord(desc *export.Descriptor, agg aggregation.Aggregation, labels *attribute.Set, end time.Time) export.Record {
+	return export.NewRecord(desc, labels, resource.Empty(), agg, time.Time{}, end)
+}
+
+func TestDefaultSanitize(t *testing.T) {
+	require.Equal(t, "http_request_duration_seconds", DefaultSanitize("http.request.duration-seconds"))
+	require.Equal(t, "already_valid", DefaultSanitize("already_valid"))
+}
+
+func TestLabelsForMergesResourceRecordAndExternalLabels(t *testing.T) {
+	desc := export.NewDescriptor("requests", 0, number.Int64Kind)
+	labels := attribute.NewSet(attribute.String("http.method", "GET"))
+	r := newTestRecord(desc, fakeSum{fakeAggregation{aggregation.SumKind}, number.NewInt64Number(1)}, &labels, time.Unix(0, 5000))
+
+	opt := Option{ExternalLabels: map[string]string{"region": "us-east-1"}}
+	res := map[string]string{"service.name": "svc"}
+
+	got := labelsFor("requests_total", res, r, opt)
+
+	want := map[string]string{
+		"__name__":     "requests_total",
+		"region":       "us-east-1",
+		"service_name": "svc",
+		"http_method":  "GET",
+	}
+	require.Len(t, got, len(want))
+	for _, l := range got {
+		require.Equal(t, want[l.Name], l.Value, "label %q", l.Name)
+	}
+}
+
+func TestCounterSeries(t *testing.T) {
+	desc := export.NewDescriptor("requests", 0, number.Int64Kind)
+	labels := attribute.NewSet()
+	r := newTestRecord(desc, fakeSum{fakeAggregation{aggregation.SumKind}, number.NewInt64Number(42)}, &labels, time.Unix(0, 7_000_000))
+
+	series, err := Record(cumulativeSelector{}, nil, Option{}, r)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Len(t, series[0].Samples, 1)
+	require.Equal(t, float64(42), series[0].Samples[0].Value)
+	require.Equal(t, int64(7), series[0].Samples[0].Timestamp)
+}
+
+func TestRecordRejectsDeltaTemporality(t *testing.T) {
+	desc := export.NewDescriptor("requests", 0, number.Int64Kind)
+	labels := attribute.NewSet()
+	r := newTestRecord(desc, fakeSum{fakeAggregation{aggregation.SumKind}, number.NewInt64Number(1)}, &labels, time.Time{})
+
+	_, err := Record(deltaSelector{}, nil, Option{}, r)
+	require.ErrorIs(t, err, ErrUnsupportedTemporality)
+}
+
+func TestGaugeSeries(t *testing.T) {
+	desc := export.NewDescriptor("queue_size", 0, number.Float64Kind)
+	labels := attribute.NewSet()
+	ts := time.Unix(0, 3_000_000)
+	r := newTestRecord(desc, fakeLastValue{fakeAggregation{aggregation.LastValueKind}, number.NewFloat64Number(3.5), ts}, &labels, time.Time{})
+
+	series, err := Record(cumulativeSelector{}, nil, Option{}, r)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Equal(t, 3.5, series[0].Samples[0].Value)
+	require.Equal(t, int64(3), series[0].Samples[0].Timestamp)
+}
+
+func TestMinMaxSumCountSeries(t *testing.T) {
+	desc := export.NewDescriptor("latency", 0, number.Float64Kind)
+	labels := attribute.NewSet()
+	agg := fakeMinMaxSumCount{
+		fakeAggregation: fakeAggregation{aggregation.MinMaxSumCountKind},
+		min:             number.NewFloat64Number(1),
+		max:             number.NewFloat64Number(9),
+		sum:             number.NewFloat64Number(20),
+		count:           4,
+	}
+	r := newTestRecord(desc, agg, &labels, time.Unix(0, 1_000_000))
+
+	series, err := Record(cumulativeSelector{}, nil, Option{}, r)
+	require.NoError(t, err)
+	require.Len(t, series, 4)
+
+	byName := make(map[string]float64, len(series))
+	for _, s := range series {
+		for _, l := range s.Labels {
+			if l.Name == "__name__" {
+				byName[l.Value] = s.Samples[0].Value
+			}
+		}
+	}
+	require.Equal(t, 1.0, byName["latency_min"])
+	require.Equal(t, 9.0, byName["latency_max"])
+	require.Equal(t, 20.0, byName["latency_sum"])
+	require.Equal(t, 4.0, byName["latency_count"])
+}
+
+func TestHistogramSeriesBucketsAndInfBucket(t *testing.T) {
+	desc := export.NewDescriptor("latency", 0, number.Float64Kind)
+	labels := attribute.NewSet()
+	agg := fakeHistogram{
+		fakeAggregation: fakeAggregation{aggregation.HistogramKind},
+		sum:             number.NewFloat64Number(30),
+		count:           6,
+		boundaries:      []float64{1, 5},
+		counts:          []uint64{2, 3, 1},
+	}
+	r := newTestRecord(desc, agg, &labels, time.Unix(0, 1_000_000))
+
+	series, err := Record(cumulativeSelector{}, nil, Option{}, r)
+	require.NoError(t, err)
+	// 2 explicit boundaries -> 2 bucket series, +1 implicit +Inf bucket, +sum, +count.
+	require.Len(t, series, 5)
+
+	cumulativeByLE := make(map[string]float64)
+	for _, s := range series {
+		var name, le string
+		for _, l := range s.Labels {
+			switch l.Name {
+			case "__name__":
+				name = l.Value
+			case "le":
+				le = l.Value
+			}
+		}
+		if name == "latency_bucket" {
+			cumulativeByLE[le] = s.Samples[0].Value
+		}
+	}
+	require.Equal(t, float64(2), cumulativeByLE["1"])
+	require.Equal(t, float64(5), cumulativeByLE["5"])
+	require.Equal(t, float64(6), cumulativeByLE["+Inf"])
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/metric/prometheusremotewrite"
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/exporters/metric/prometheusremotewrite/internal/transform"
+)
+
+// config contains options for the Exporter.
+type config struct {
+	Endpoint        string
+	Client          *http.Client
+	Headers         map[string]string
+	NumWorkers      uint
+	TransformOption transform.Option
+}
+
+// Option sets an option for the Exporter.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(cfg *config) {
+	f(cfg)
+}
+
+// WithEndpoint sets the URL the Exporter sends remote write requests to.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Endpoint = endpoint
+	})
+}
+
+// WithClient sets the http.Client used to send remote write requests. If
+// this is not set the exporter uses http.DefaultClient.
+func WithClient(client *http.Client) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Client = client
+	})
+}
+
+// WithBearerToken configures the Exporter to send requests with an
+// `Authorization: Bearer <token>` header.
+func WithBearerToken(token string) Option {
+	return optionFunc(func(cfg *config) {
+		if cfg.Headers == nil {
+			cfg.Headers = map[string]string{}
+		}
+		cfg.Headers["Authorization"] = "Bearer " + token
+	})
+}
+
+// WithBasicAuth configures the Exporter to send requests with HTTP basic
+// authentication credentials.
+func WithBasicAuth(username, password string) Option {
+	return optionFunc(func(cfg *config) {
+		if cfg.Headers == nil {
+			cfg.Headers = map[string]string{}
+		}
+		cfg.Headers["Authorization"] = "Basic " + basicAuthHeader(username, password)
+	})
+}
+
+// WithExternalLabels sets labels that are attached to every series sent by
+// the Exporter, in addition to the resource and record labels.
+func WithExternalLabels(labels map[string]string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.TransformOption.ExternalLabels = labels
+	})
+}
+
+// WithNumWorkers sets the number of goroutines used to transform
+// checkpoints concurrently. The default is 1.
+func WithNumWorkers(n uint) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.NumWorkers = n
+	})
+}
+
+// WithNameSanitizer sets the function used to sanitize metric and label
+// names before they are sent to Prometheus. The default replaces any
+// character outside of `[a-zA-Z0-9_]` with `_`.
+func WithNameSanitizer(sanitize func(string) string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.TransformOption.Sanitize = sanitize
+	})
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		Client:     http.DefaultClient,
+		NumWorkers: 1,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
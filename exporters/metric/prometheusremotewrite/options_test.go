@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigDefaults(t *testing.T) {
+	cfg := newConfig()
+	require.Equal(t, http.DefaultClient, cfg.Client)
+	require.EqualValues(t, 1, cfg.NumWorkers)
+}
+
+// TestWithExternalLabelsReachesTransformOption guards against the option
+// silently dropping labels: it must write through to
+// cfg.TransformOption.ExternalLabels, the field labelsFor actually reads.
+func TestWithExternalLabelsReachesTransformOption(t *testing.T) {
+	cfg := newConfig(WithExternalLabels(map[string]string{"region": "us-east-1"}))
+	require.Equal(t, map[string]string{"region": "us-east-1"}, cfg.TransformOption.ExternalLabels)
+}
+
+func TestWithBasicAuthSetsAuthorizationHeader(t *testing.T) {
+	cfg := newConfig(WithBasicAuth("user", "pass"))
+	require.Equal(t, "Basic "+basicAuthHeader("user", "pass"), cfg.Headers["Authorization"])
+}
+
+func TestWithBearerTokenSetsAuthorizationHeader(t *testing.T) {
+	cfg := newConfig(WithBearerToken("tok123"))
+	require.Equal(t, "Bearer tok123", cfg.Headers["Authorization"])
+}
+
+func TestWithNameSanitizerReachesTransformOption(t *testing.T) {
+	cfg := newConfig(WithNameSanitizer(func(string) string { return "x" }))
+	require.Equal(t, "x", cfg.TransformOption.Sanitize("anything"))
+}
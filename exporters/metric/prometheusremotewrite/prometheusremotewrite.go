@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/metric/prometheusremotewrite"
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/otel/exporters/metric/prometheusremotewrite/internal/transform"
+)
+
+// Exporter transforms OpenTelemetry metric checkpoints into Prometheus
+// remote write requests and POSTs them, Snappy-compressed, to a remote
+// write endpoint.
+type Exporter struct {
+	cfg config
+}
+
+var _ export.Exporter = (*Exporter)(nil)
+
+// New returns a new Exporter configured to write to the endpoint set by
+// WithEndpoint.
+func New(opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("prometheusremotewrite: no endpoint configured")
+	}
+	return &Exporter{cfg: cfg}, nil
+}
+
+// ExportKindFor always returns CumulativeExportKind, as Prometheus remote
+// write does not support delta temporality.
+func (e *Exporter) ExportKindFor(*export.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.CumulativeExportKind
+}
+
+// Export transforms a CheckpointSet into a prompb.WriteRequest and sends
+// it to the configured endpoint.
+func (e *Exporter) Export(ctx context.Context, res *resource.Resource, cps export.CheckpointSet) error {
+	series, err := transform.CheckpointSet(ctx, e, cps, resourceLabels(res), e.cfg.NumWorkers, e.cfg.TransformOption)
+	if err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		return nil
+	}
+
+	wr := &prompb.WriteRequest{Timeseries: series}
+	body, err := proto.Marshal(wr)
+	if err != nil {
+		return fmt.Errorf("prometheusremotewrite: marshaling write request: %w", err)
+	}
+	compressed := snappy.Encode(nil, body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Endpoint, bytes.NewReader(compressed))
+	if err != nil {
+		return fmt.Errorf("prometheusremotewrite: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("prometheusremotewrite: sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("prometheusremotewrite: remote write returned %s: %s", resp.Status, string(b))
+	}
+	return nil
+}
+
+// resourceLabels flattens a Resource's attributes into the label set
+// attached to every series exported for a CheckpointSet.
+func resourceLabels(res *resource.Resource) map[string]string {
+	if res == nil {
+		return nil
+	}
+	iter := res.Iter()
+	labels := make(map[string]string, iter.Len())
+	for iter.Next() {
+		kv := iter.Label()
+		labels[string(kv.Key)] = kv.Value.Emit()
+	}
+	return labels
+}
+
+func basicAuthHeader(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestNewRequiresEndpoint(t *testing.T) {
+	_, err := New()
+	require.Error(t, err)
+}
+
+func TestNewWithEndpoint(t *testing.T) {
+	exp, err := New(WithEndpoint("http://localhost:9090/api/v1/write"))
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+}
+
+func TestExportKindForAlwaysCumulative(t *testing.T) {
+	exp, err := New(WithEndpoint("http://localhost:9090/api/v1/write"))
+	require.NoError(t, err)
+	require.Equal(t, export.CumulativeExportKind, exp.ExportKindFor(nil, aggregation.SumKind))
+	require.Equal(t, export.CumulativeExportKind, exp.ExportKindFor(nil, aggregation.HistogramKind))
+}
+
+func TestResourceLabels(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "my-service"))
+	require.Equal(t, map[string]string{"service.name": "my-service"}, resourceLabels(res))
+}
+
+func TestResourceLabelsNilResource(t *testing.T) {
+	require.Nil(t, resourceLabels(nil))
+}
+
+func TestBasicAuthHeader(t *testing.T) {
+	require.Equal(t, "dXNlcjpwYXNz", basicAuthHeader("user", "pass"))
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform // import "go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// overflowMeter reports diagnostics about the cardinality limiter so
+// operators can see when WithMaxSeriesPerMetric is triggering in
+// production without having to inspect exported series directly.
+var overflowMeter = global.Meter("go.opentelemetry.io/otel/exporters/otlp/internal/transform")
+
+var overflowCounter = metric.Must(overflowMeter).NewInt64Counter(
+	"otel.transform.cardinality_overflow",
+	metric.WithDescription("Number of data points merged into an overflow series because a metric exceeded its configured cardinality limit."),
+)
+
+// cardinalityLimiter bounds the number of distinct label sets accepted
+// for each metric name within a single checkpoint. It is safe for
+// concurrent use by the transformer goroutines that share a checkpoint.
+type cardinalityLimiter struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]map[attribute.Distinct]struct{}
+}
+
+// newCardinalityLimiter returns a cardinalityLimiter that admits at most
+// max distinct label sets per metric name, or nil (disabling the limit)
+// if max is not positive.
+func newCardinalityLimiter(max int) *cardinalityLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &cardinalityLimiter{
+		max:  max,
+		seen: make(map[string]map[attribute.Distinct]struct{}),
+	}
+}
+
+// admit reports whether a label set identified by distinct should keep
+// its own series for the metric name. Once name has accumulated max
+// distinct label sets, any previously unseen label set overflows and
+// admit returns false.
+func (l *cardinalityLimiter) admit(name string, distinct attribute.Distinct) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	set, ok := l.seen[name]
+	if !ok {
+		set = make(map[attribute.Distinct]struct{})
+		l.seen[name] = set
+	}
+	if _, ok := set[distinct]; ok {
+		return true
+	}
+	if len(set) >= l.max {
+		return false
+	}
+	set[distinct] = struct{}{}
+	return true
+}
+
+// effectiveLabels computes the label set a Record should be exported
+// with: labels on cfg.LabelDenyList are dropped, labels not on
+// cfg.LabelAllowList[name] (when one is configured for name) are
+// dropped, and, if limiter reports the resulting label set as
+// overflowing name's cardinality limit, all labels are replaced with
+// cfg.OverflowLabel.
+//
+// Filtering happens before the cardinality check so that a deny-listed
+// label actually reduces the distinct series admitted per metric, and so
+// that two raw label sets that collapse to the same filtered set count
+// as one series rather than two.
+func effectiveLabels(r export.Record, name string, cfg config, limiter *cardinalityLimiter) *attribute.Set {
+	filtered := filterLabels(r.Labels(), name, cfg)
+
+	if !limiter.admit(name, filtered.Equivalent()) {
+		overflowCounter.Add(context.Background(), 1, attribute.String("metric_name", name))
+		overflow := cfg.OverflowLabel
+		if overflow == (attribute.KeyValue{}) {
+			overflow = defaultOverflowLabel
+		}
+		set := attribute.NewSet(overflow)
+		return &set
+	}
+
+	return filtered
+}
+
+// filterLabels applies cfg.LabelDenyList and cfg.LabelAllowList[name] to
+// base, returning base unmodified if neither is configured.
+func filterLabels(base *attribute.Set, name string, cfg config) *attribute.Set {
+	if len(cfg.LabelDenyList) == 0 && cfg.LabelAllowList == nil {
+		return base
+	}
+
+	allow, hasAllowList := cfg.LabelAllowList[name]
+	var allowed map[string]struct{}
+	if hasAllowList {
+		allowed = make(map[string]struct{}, len(allow))
+		for _, k := range allow {
+			allowed[k] = struct{}{}
+		}
+	}
+
+	var kvs []attribute.KeyValue
+	iter := base.Iter()
+	for iter.Next() {
+		kv := iter.Label()
+		if isDenied(cfg.LabelDenyList, kv.Key) {
+			continue
+		}
+		if hasAllowList {
+			if _, ok := allowed[string(kv.Key)]; !ok {
+				continue
+			}
+		}
+		kvs = append(kvs, kv)
+	}
+	set := attribute.NewSet(kvs...)
+	return &set
+}
+
+func isDenied(denyList []attribute.Key, k attribute.Key) bool {
+	for _, d := range denyList {
+		if d == k {
+			return true
+		}
+	}
+	return false
+}
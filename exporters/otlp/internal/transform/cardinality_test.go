@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func newLabeledRecord(labels attribute.Set) export.Record {
+	desc := export.NewDescriptor("m", 0, number.Int64Kind)
+	return export.NewRecord(desc, &labels, resource.Empty(), testSum{number.NewInt64Number(1)}, time.Time{}, time.Time{})
+}
+
+func newLabeledSumRecord(name string, v int64, labels attribute.Set, res *resource.Resource) export.Record {
+	desc := export.NewDescriptor(name, 0, number.Int64Kind)
+	return export.NewRecord(desc, &labels, res, testSum{number.NewInt64Number(v)}, time.Time{}, time.Time{})
+}
+
+func TestFilterLabelsDenyList(t *testing.T) {
+	base := attribute.NewSet(attribute.String("route", "/x"), attribute.String("user.id", "42"))
+	cfg := config{LabelDenyList: []attribute.Key{"user.id"}}
+
+	got := filterLabels(&base, "m", cfg)
+
+	iter := got.Iter()
+	require.Equal(t, 1, iter.Len())
+	require.True(t, iter.Next())
+	require.Equal(t, attribute.Key("route"), iter.Label().Key)
+}
+
+func TestFilterLabelsAllowList(t *testing.T) {
+	base := attribute.NewSet(attribute.String("route", "/x"), attribute.String("user.id", "42"))
+	cfg := config{LabelAllowList: map[string][]string{"m": {"route"}}}
+
+	got := filterLabels(&base, "m", cfg)
+
+	iter := got.Iter()
+	require.Equal(t, 1, iter.Len())
+	require.True(t, iter.Next())
+	require.Equal(t, attribute.Key("route"), iter.Label().Key)
+}
+
+func TestFilterLabelsAllowListLeavesOtherMetricsAlone(t *testing.T) {
+	base := attribute.NewSet(attribute.String("route", "/x"), attribute.String("user.id", "42"))
+	cfg := config{LabelAllowList: map[string][]string{"other": {"route"}}}
+
+	got := filterLabels(&base, "m", cfg)
+
+	require.Equal(t, base.Equivalent(), got.Equivalent())
+}
+
+func TestCardinalityLimiterAdmitsUpToMax(t *testing.T) {
+	limiter := newCardinalityLimiter(2)
+
+	a := attribute.NewSet(attribute.String("route", "/a")).Equivalent()
+	b := attribute.NewSet(attribute.String("route", "/b")).Equivalent()
+	c := attribute.NewSet(attribute.String("route", "/c")).Equivalent()
+
+	require.True(t, limiter.admit("reqs", a))
+	require.True(t, limiter.admit("reqs", b))
+	require.False(t, limiter.admit("reqs", c), "a third distinct label set should overflow a limit of 2")
+	require.True(t, limiter.admit("reqs", a), "a previously admitted label set keeps its own series")
+}
+
+func TestNilCardinalityLimiterAdmitsEverything(t *testing.T) {
+	var limiter *cardinalityLimiter
+	require.True(t, limiter.admit("reqs", attribute.NewSet().Equivalent()))
+}
+
+// TestEffectiveLabelsFiltersBeforeCardinalityCheck guards against the
+// ordering bug where the cardinality check ran against the raw,
+// pre-filter label set: two raw sets that differ only in a denied label
+// must collapse to the same filtered set and be admitted as a single
+// series, not counted as two distinct ones.
+func TestEffectiveLabelsFiltersBeforeCardinalityCheck(t *testing.T) {
+	cfg := config{LabelDenyList: []attribute.Key{"user.id"}}
+	limiter := newCardinalityLimiter(1)
+
+	setA := attribute.NewSet(attribute.String("user.id", "1"), attribute.String("route", "/x"))
+	setB := attribute.NewSet(attribute.String("user.id", "2"), attribute.String("route", "/x"))
+
+	got1 := effectiveLabels(newLabeledRecord(setA), "m", cfg, limiter)
+	got2 := effectiveLabels(newLabeledRecord(setB), "m", cfg, limiter)
+
+	require.Equal(t, got1.Equivalent(), got2.Equivalent())
+	iter := got1.Iter()
+	require.Equal(t, 1, iter.Len())
+}
+
+func TestEffectiveLabelsOverflowsToOverflowLabel(t *testing.T) {
+	cfg := config{}
+	limiter := newCardinalityLimiter(1)
+
+	setA := attribute.NewSet(attribute.String("route", "/a"))
+	setB := attribute.NewSet(attribute.String("route", "/b"))
+
+	got1 := effectiveLabels(newLabeledRecord(setA), "m", cfg, limiter)
+	got2 := effectiveLabels(newLabeledRecord(setB), "m", cfg, limiter)
+
+	require.Equal(t, setA.Equivalent(), got1.Equivalent())
+
+	iter := got2.Iter()
+	require.True(t, iter.Next())
+	require.Equal(t, defaultOverflowLabel, iter.Label())
+}
+
+// TestCheckpointSetMergesOverflowingRecordsIntoOneSeries exercises the
+// cardinality limiter end to end: once a metric's distinct label sets
+// exceed MaxSeriesPerMetric, every further Record must fold into the
+// same overflow data point rather than producing duplicate,
+// identically-labeled points.
+func TestCheckpointSetMergesOverflowingRecordsIntoOneSeries(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	cps := fakeCheckpointSet{records: []export.Record{
+		newLabeledSumRecord("reqs", 1, attribute.NewSet(attribute.String("route", "/a")), res),
+		newLabeledSumRecord("reqs", 2, attribute.NewSet(attribute.String("route", "/b")), res),
+		newLabeledSumRecord("reqs", 3, attribute.NewSet(attribute.String("route", "/c")), res),
+	}}
+
+	rms, err := CheckpointSet(context.Background(), cumulativeSelector{}, cps, 1, WithMaxSeriesPerMetric(1))
+	require.NoError(t, err)
+	require.Len(t, rms, 1)
+
+	metrics := rms[0].InstrumentationLibraryMetrics[0].Metrics
+	require.Len(t, metrics, 1)
+
+	points := metrics[0].GetSum().DataPoints
+	require.Len(t, points, 2, "the first admitted series plus one merged overflow series")
+
+	byKind := make(map[string]int64, 2)
+	for _, dp := range points {
+		if len(dp.Attributes) == 1 && dp.Attributes[0].Key == "otel_overflow" {
+			byKind["overflow"] = dp.GetAsInt()
+		} else {
+			byKind["admitted"] = dp.GetAsInt()
+		}
+	}
+	require.Equal(t, int64(1), byKind["admitted"])
+	require.Equal(t, int64(5), byKind["overflow"], "overflow point must accumulate, not duplicate, the folded-in records")
+}
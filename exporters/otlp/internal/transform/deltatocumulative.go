@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform // import "go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DeltaToCumulativeState is the running total DeltaToCumulative tracks
+// for one series.
+type DeltaToCumulativeState struct {
+	// StartTimeUnixNano is the start time of the first delta point seen
+	// for this series since it was last reset (by a counter reset or by
+	// DeltaToCumulative.Reset).
+	StartTimeUnixNano uint64
+	IntTotal          int64
+	FloatTotal        float64
+}
+
+// DeltaToCumulativeStore holds the per-series DeltaToCumulativeState that
+// DeltaToCumulative needs to rewrite delta Sums into cumulative Sums. The
+// default store, used when DeltaToCumulative is constructed with no
+// DeltaToCumulativeOption, never evicts entries; callers exporting an
+// unbounded or very large set of series can plug in a bounded
+// implementation (e.g. an LRU) with WithDeltaToCumulativeStore.
+type DeltaToCumulativeStore interface {
+	Load(key string) (state DeltaToCumulativeState, ok bool)
+	Store(key string, state DeltaToCumulativeState)
+	Reset()
+}
+
+// mapStore is the default, unbounded DeltaToCumulativeStore.
+type mapStore struct {
+	mu sync.Mutex
+	m  map[string]DeltaToCumulativeState
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{m: make(map[string]DeltaToCumulativeState)}
+}
+
+func (s *mapStore) Load(key string) (DeltaToCumulativeState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.m[key]
+	return state, ok
+}
+
+func (s *mapStore) Store(key string, state DeltaToCumulativeState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = state
+}
+
+func (s *mapStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m = make(map[string]DeltaToCumulativeState)
+}
+
+// DeltaToCumulativeOption configures a DeltaToCumulative.
+type DeltaToCumulativeOption func(*DeltaToCumulative)
+
+// WithDeltaToCumulativeStore installs a custom DeltaToCumulativeStore,
+// letting callers bound the memory a long-running DeltaToCumulative
+// stage uses by supplying an evicting (e.g. LRU) implementation in place
+// of the unbounded default.
+func WithDeltaToCumulativeStore(store DeltaToCumulativeStore) DeltaToCumulativeOption {
+	return func(d *DeltaToCumulative) {
+		d.store = store
+	}
+}
+
+// DeltaToCumulative is a stateful pipeline stage that rewrites
+// delta-temporality Sum metrics into cumulative-temporality Sums by
+// maintaining a running total per series (resource, instrumentation
+// library, metric name, and attribute set). It sits between transformer
+// and sink, installed via WithForceCumulative, for backends that require
+// cumulative counters.
+//
+// Because the running totals must survive across export cycles, callers
+// construct one DeltaToCumulative with NewDeltaToCumulative and reuse it
+// across repeated calls to CheckpointSet/CheckpointSetStream.
+type DeltaToCumulative struct {
+	store DeltaToCumulativeStore
+}
+
+// NewDeltaToCumulative returns a DeltaToCumulative stage backed by an
+// unbounded in-memory store, unless overridden with
+// WithDeltaToCumulativeStore.
+func NewDeltaToCumulative(opts ...DeltaToCumulativeOption) *DeltaToCumulative {
+	d := &DeltaToCumulative{store: newMapStore()}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Reset discards all running totals, as if no delta Sums had been
+// observed.
+func (d *DeltaToCumulative) Reset() {
+	d.store.Reset()
+}
+
+// Convert rewrites m in place if it holds a delta-temporality Sum,
+// returning the (possibly unmodified) Metric. Non-Sum metrics and
+// cumulative Sums are returned unchanged.
+func (d *DeltaToCumulative) Convert(res *resource.Resource, il instrumentation.Library, m *metricpb.Metric) *metricpb.Metric {
+	sum, ok := m.Data.(*metricpb.Metric_Sum)
+	if !ok || sum.Sum.AggregationTemporality != metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+		return m
+	}
+
+	for _, dp := range sum.Sum.DataPoints {
+		key := deltaSeriesKey(res, il, m.Name, dp.Attributes)
+		state, ok := d.store.Load(key)
+
+		switch v := dp.Value.(type) {
+		case *metricpb.NumberDataPoint_AsInt:
+			// For monotonic sums a decrease indicates the underlying
+			// counter was reset (e.g. process restart); start a fresh
+			// running total from this point rather than going
+			// cumulative negative. Non-monotonic sums (UpDownCounters)
+			// legitimately decrease, so a negative delta there is just
+			// accumulated like any other.
+			if !ok || (sum.Sum.IsMonotonic && v.AsInt < 0) {
+				state = DeltaToCumulativeState{StartTimeUnixNano: dp.StartTimeUnixNano, IntTotal: v.AsInt}
+			} else {
+				state.IntTotal += v.AsInt
+			}
+			v.AsInt = state.IntTotal
+
+		case *metricpb.NumberDataPoint_AsDouble:
+			if !ok || (sum.Sum.IsMonotonic && v.AsDouble < 0) {
+				state = DeltaToCumulativeState{StartTimeUnixNano: dp.StartTimeUnixNano, FloatTotal: v.AsDouble}
+			} else {
+				state.FloatTotal += v.AsDouble
+			}
+			v.AsDouble = state.FloatTotal
+		}
+
+		dp.StartTimeUnixNano = state.StartTimeUnixNano
+		d.store.Store(key, state)
+	}
+
+	sum.Sum.AggregationTemporality = metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	return m
+}
+
+// stream applies Convert to every result read from in, forwarding each
+// (possibly rewritten) result to the returned chan.
+func (d *DeltaToCumulative) stream(ctx context.Context, in <-chan result) <-chan result {
+	out := make(chan result)
+	go func() {
+		defer close(out)
+		for res := range in {
+			if res.Err == nil && res.Metric != nil {
+				res.Metric = d.Convert(res.Resource, res.InstrumentationLibrary, res.Metric)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- res:
+			}
+		}
+	}()
+	return out
+}
+
+// deltaSeriesKey identifies the series a data point belongs to, so its
+// running total can be looked up across export cycles.
+func deltaSeriesKey(res *resource.Resource, il instrumentation.Library, name string, attrs []*commonpb.KeyValue) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v|%s|%s|%s|", res.Equivalent(), il.Name, il.Version, name)
+
+	sorted := append([]*commonpb.KeyValue(nil), attrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	for _, kv := range sorted {
+		fmt.Fprintf(h, "%s=%v;", kv.Key, kv.Value)
+	}
+	return fmt.Sprintf("%x", h.Sum64())
+}
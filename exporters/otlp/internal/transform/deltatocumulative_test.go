@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func deltaSumMetric(name string, monotonic bool, startNano, endNano uint64, v int64) *metricpb.Metric {
+	return &metricpb.Metric{
+		Name: name,
+		Data: &metricpb.Metric_Sum{
+			Sum: &metricpb.Sum{
+				IsMonotonic:            monotonic,
+				AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+				DataPoints: []*metricpb.NumberDataPoint{
+					{
+						StartTimeUnixNano: startNano,
+						TimeUnixNano:      endNano,
+						Value:             &metricpb.NumberDataPoint_AsInt{AsInt: v},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertAccumulatesDeltaSumIntoCumulative(t *testing.T) {
+	d := NewDeltaToCumulative()
+	res := resource.Empty()
+	il := instrumentation.Library{Name: "test"}
+
+	m1 := d.Convert(res, il, deltaSumMetric("reqs", true, 100, 200, 5))
+	require.Equal(t, metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, m1.GetSum().AggregationTemporality)
+	require.EqualValues(t, 5, m1.GetSum().DataPoints[0].GetAsInt())
+	require.EqualValues(t, 100, m1.GetSum().DataPoints[0].StartTimeUnixNano)
+
+	m2 := d.Convert(res, il, deltaSumMetric("reqs", true, 200, 300, 3))
+	require.EqualValues(t, 8, m2.GetSum().DataPoints[0].GetAsInt(), "cumulative total should add the second delta to the first")
+	require.EqualValues(t, 100, m2.GetSum().DataPoints[0].StartTimeUnixNano, "start time should stay pinned to the first point seen for the series")
+}
+
+func TestConvertResetsOnNegativeDeltaForMonotonicSum(t *testing.T) {
+	d := NewDeltaToCumulative()
+	res := resource.Empty()
+	il := instrumentation.Library{Name: "test"}
+
+	d.Convert(res, il, deltaSumMetric("reqs", true, 100, 200, 5))
+	m2 := d.Convert(res, il, deltaSumMetric("reqs", true, 200, 300, -2))
+
+	require.EqualValues(t, -2, m2.GetSum().DataPoints[0].GetAsInt(), "a negative delta on a monotonic sum is a counter reset, not a subtraction")
+	require.EqualValues(t, 200, m2.GetSum().DataPoints[0].StartTimeUnixNano, "a counter reset should start a fresh window")
+}
+
+func TestConvertAccumulatesNegativeDeltaForNonMonotonicSum(t *testing.T) {
+	d := NewDeltaToCumulative()
+	res := resource.Empty()
+	il := instrumentation.Library{Name: "test"}
+
+	d.Convert(res, il, deltaSumMetric("active_requests", false, 100, 200, 5))
+	m2 := d.Convert(res, il, deltaSumMetric("active_requests", false, 200, 300, -2))
+
+	require.EqualValues(t, 3, m2.GetSum().DataPoints[0].GetAsInt(), "a negative delta on a non-monotonic sum is a normal decrease, not a reset")
+	require.EqualValues(t, 100, m2.GetSum().DataPoints[0].StartTimeUnixNano, "the running total should not be reset for an UpDownCounter decrease")
+}
+
+func TestConvertLeavesCumulativeSumsUnchanged(t *testing.T) {
+	d := NewDeltaToCumulative()
+	m := &metricpb.Metric{
+		Data: &metricpb.Metric_Sum{
+			Sum: &metricpb.Sum{
+				AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				DataPoints: []*metricpb.NumberDataPoint{
+					{Value: &metricpb.NumberDataPoint_AsInt{AsInt: 42}},
+				},
+			},
+		},
+	}
+
+	got := d.Convert(resource.Empty(), instrumentation.Library{}, m)
+	require.EqualValues(t, 42, got.GetSum().DataPoints[0].GetAsInt())
+}
+
+func TestConvertLeavesNonSumMetricsUnchanged(t *testing.T) {
+	d := NewDeltaToCumulative()
+	m := &metricpb.Metric{Data: &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{}}}
+
+	got := d.Convert(resource.Empty(), instrumentation.Library{}, m)
+	require.Same(t, m, got)
+}
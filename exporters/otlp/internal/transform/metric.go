@@ -76,7 +76,34 @@ func toNanos(t time.Time) uint64 {
 
 // CheckpointSet transforms all records contained in a checkpoint into
 // batched OTLP ResourceMetrics.
-func CheckpointSet(ctx context.Context, exportSelector export.ExportKindSelector, cps export.CheckpointSet, numWorkers uint) ([]*metricpb.ResourceMetrics, error) {
+//
+// This is a thin wrapper around CheckpointSetStream that drains the
+// returned stream into a single slice. Prefer CheckpointSetStream when
+// exporting checkpoints with a very large number of series, since it lets
+// the caller start transmitting ResourceMetrics as soon as they are ready
+// instead of holding the whole checkpoint in memory.
+func CheckpointSet(ctx context.Context, exportSelector export.ExportKindSelector, cps export.CheckpointSet, numWorkers uint, opts ...Option) ([]*metricpb.ResourceMetrics, error) {
+	stream, errc := CheckpointSetStream(ctx, exportSelector, cps, numWorkers, opts...)
+
+	var rms []*metricpb.ResourceMetrics
+	for rm := range stream {
+		rms = append(rms, rm)
+	}
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return rms, nil
+}
+
+// CheckpointSetStream transforms all records contained in a checkpoint
+// into OTLP ResourceMetrics, sending each one on the returned chan as
+// soon as it is ready rather than collecting the whole checkpoint into a
+// single slice. The returned error chan receives at most one error, after
+// the ResourceMetrics chan has been closed.
+func CheckpointSetStream(ctx context.Context, exportSelector export.ExportKindSelector, cps export.CheckpointSet, numWorkers uint, opts ...Option) (<-chan *metricpb.ResourceMetrics, <-chan error) {
+	cfg := newConfig(opts...)
+	limiter := newCardinalityLimiter(cfg.MaxSeriesPerMetric)
 	records, errc := source(ctx, exportSelector, cps)
 
 	// Start a fixed number of goroutines to transform records.
@@ -86,7 +113,7 @@ func CheckpointSet(ctx context.Context, exportSelector export.ExportKindSelector
 	for i := uint(0); i < numWorkers; i++ {
 		go func() {
 			defer wg.Done()
-			transformer(ctx, exportSelector, records, transformed)
+			transformer(ctx, exportSelector, cfg, limiter, records, transformed)
 		}()
 	}
 	go func() {
@@ -94,17 +121,26 @@ func CheckpointSet(ctx context.Context, exportSelector export.ExportKindSelector
 		close(transformed)
 	}()
 
-	// Synchronously collect the transformed records and transmit.
-	rms, err := sink(ctx, transformed)
-	if err != nil {
-		return nil, err
+	// Stage delta-to-cumulative conversion, if configured, between the
+	// transformer workers and the sink.
+	var stage <-chan result = transformed
+	if cfg.ForceCumulative != nil {
+		stage = cfg.ForceCumulative.stream(ctx, transformed)
 	}
 
-	// source is complete, check for any errors.
-	if err := <-errc; err != nil {
-		return nil, err
-	}
-	return rms, nil
+	out := make(chan *metricpb.ResourceMetrics)
+	outErrc := make(chan error, 1)
+	go func() {
+		err := sink(ctx, stage, cfg, out)
+		if err == nil {
+			// source is complete, check for any errors.
+			err = <-errc
+		}
+		close(out)
+		outErrc <- err
+	}()
+
+	return out, outErrc
 }
 
 // source starts a goroutine that sends each one of the Records yielded by
@@ -131,9 +167,9 @@ func source(ctx context.Context, exportSelector export.ExportKindSelector, cps e
 
 // transformer transforms records read from the passed in chan into
 // OTLP Metrics which are sent on the out chan.
-func transformer(ctx context.Context, exportSelector export.ExportKindSelector, in <-chan export.Record, out chan<- result) {
+func transformer(ctx context.Context, exportSelector export.ExportKindSelector, cfg config, limiter *cardinalityLimiter, in <-chan export.Record, out chan<- result) {
 	for r := range in {
-		m, err := Record(exportSelector, r)
+		m, err := recordWithConfig(exportSelector, r, cfg, limiter)
 		// Propagate errors, but do not send empty results.
 		if err == nil && m == nil {
 			continue
@@ -155,22 +191,88 @@ func transformer(ctx context.Context, exportSelector export.ExportKindSelector,
 	}
 }
 
-// sink collects transformed Records and batches them.
+// resourceBatch accumulates the OTLP Metrics seen for a single Resource
+// since the last time it was flushed to the output chan.
+type resourceBatch struct {
+	Resource *resourcepb.Resource
+	// Group by instrumentation library name and then the MetricDescriptor.
+	InstrumentationLibraryBatches map[instrumentation.Library]map[string]*metricpb.Metric
+	// points is the number of data points accumulated across all metrics
+	// in this batch since it was last flushed.
+	points int
+}
+
+// dataPointCount returns the number of data points held by m.
+func dataPointCount(m *metricpb.Metric) int {
+	switch d := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		return len(d.Gauge.DataPoints)
+	case *metricpb.Metric_Sum:
+		return len(d.Sum.DataPoints)
+	case *metricpb.Metric_Histogram:
+		return len(d.Histogram.DataPoints)
+	case *metricpb.Metric_ExponentialHistogram:
+		return len(d.ExponentialHistogram.DataPoints)
+	case *metricpb.Metric_Summary:
+		return len(d.Summary.DataPoints)
+	default:
+		return 0
+	}
+}
+
+// build converts an accumulated resourceBatch into a metricpb.ResourceMetrics.
+func (rb *resourceBatch) build() *metricpb.ResourceMetrics {
+	if len(rb.InstrumentationLibraryBatches) == 0 {
+		return nil
+	}
+	rm := &metricpb.ResourceMetrics{Resource: rb.Resource}
+	for il, mb := range rb.InstrumentationLibraryBatches {
+		ilm := &metricpb.InstrumentationLibraryMetrics{
+			Metrics: make([]*metricpb.Metric, 0, len(mb)),
+		}
+		if il != (instrumentation.Library{}) {
+			ilm.InstrumentationLibrary = &commonpb.InstrumentationLibrary{
+				Name:    il.Name,
+				Version: il.Version,
+			}
+		}
+		for _, m := range mb {
+			ilm.Metrics = append(ilm.Metrics, m)
+		}
+		rm.InstrumentationLibraryMetrics = append(rm.InstrumentationLibraryMetrics, ilm)
+	}
+	return rm
+}
+
+// sink collects transformed Records, batches them by Resource, and sends
+// each batch on out as soon as its accumulated data-point count crosses
+// cfg.MaxPointsPerBatch (if set), rather than holding the whole
+// checkpoint in memory until input is drained. Any remaining batches are
+// flushed once in is closed.
 //
 // Any errors encoutered transforming input will be reported with an
-// ErrTransforming as well as the completed ResourceMetrics. It is up to the
-// caller to handle any incorrect data in these ResourceMetrics.
-func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, error) {
+// ErrTransforming, but do not stop ResourceMetrics that did transform
+// successfully from being sent on out.
+func sink(ctx context.Context, in <-chan result, cfg config, out chan<- *metricpb.ResourceMetrics) error {
 	var errStrings []string
 
-	type resourceBatch struct {
-		Resource *resourcepb.Resource
-		// Group by instrumentation library name and then the MetricDescriptor.
-		InstrumentationLibraryBatches map[instrumentation.Library]map[string]*metricpb.Metric
+	// group by unique Resource string.
+	grouped := make(map[attribute.Distinct]*resourceBatch)
+
+	flush := func(rID attribute.Distinct, rb *resourceBatch) bool {
+		rm := rb.build()
+		delete(grouped, rID)
+		if rm == nil {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case out <- rm:
+			return true
+		}
 	}
 
-	// group by unique Resource string.
-	grouped := make(map[attribute.Distinct]resourceBatch)
 	for res := range in {
 		if res.Err != nil {
 			errStrings = append(errStrings, res.Err.Error())
@@ -180,7 +282,7 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 		rID := res.Resource.Equivalent()
 		rb, ok := grouped[rID]
 		if !ok {
-			rb = resourceBatch{
+			rb = &resourceBatch{
 				Resource:                      Resource(res.Resource),
 				InstrumentationLibraryBatches: make(map[instrumentation.Library]map[string]*metricpb.Metric),
 			}
@@ -197,58 +299,300 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 		m, ok := mb[mID]
 		if !ok {
 			mb[mID] = res.Metric
-			continue
+			rb.points += dataPointCount(res.Metric)
+		} else {
+			before := dataPointCount(m)
+			if err := mergeDataPoints(m, res.Metric); err != nil {
+				errStrings = append(errStrings, err.Error())
+				continue
+			}
+			rb.points += dataPointCount(m) - before
 		}
-		switch res.Metric.Data.(type) {
-		case *metricpb.Metric_Gauge:
-			m.GetGauge().DataPoints = append(m.GetGauge().DataPoints, res.Metric.GetGauge().DataPoints...)
-		case *metricpb.Metric_Sum:
-			m.GetSum().DataPoints = append(m.GetSum().DataPoints, res.Metric.GetSum().DataPoints...)
-		case *metricpb.Metric_Histogram:
-			m.GetHistogram().DataPoints = append(m.GetHistogram().DataPoints, res.Metric.GetHistogram().DataPoints...)
-		case *metricpb.Metric_Summary:
-			m.GetSummary().DataPoints = append(m.GetSummary().DataPoints, res.Metric.GetSummary().DataPoints...)
-		default:
-			err := fmt.Sprintf("unsupported metric type: %T", res.Metric.Data)
-			errStrings = append(errStrings, err)
+
+		if cfg.MaxPointsPerBatch > 0 && rb.points >= cfg.MaxPointsPerBatch {
+			if !flush(rID, rb) {
+				return ErrContextCanceled
+			}
 		}
 	}
 
-	if len(grouped) == 0 {
-		return nil, nil
+	for rID, rb := range grouped {
+		if !flush(rID, rb) {
+			return ErrContextCanceled
+		}
 	}
 
-	var rms []*metricpb.ResourceMetrics
-	for _, rb := range grouped {
-		rm := &metricpb.ResourceMetrics{Resource: rb.Resource}
-		for il, mb := range rb.InstrumentationLibraryBatches {
-			ilm := &metricpb.InstrumentationLibraryMetrics{
-				Metrics: make([]*metricpb.Metric, 0, len(mb)),
-			}
-			if il != (instrumentation.Library{}) {
-				ilm.InstrumentationLibrary = &commonpb.InstrumentationLibrary{
-					Name:    il.Name,
-					Version: il.Version,
-				}
-			}
-			for _, m := range mb {
-				ilm.Metrics = append(ilm.Metrics, m)
+	if len(errStrings) > 0 {
+		return fmt.Errorf("%w:\n -%s", ErrTransforming, strings.Join(errStrings, "\n -"))
+	}
+	return nil
+}
+
+// dataPointKey returns a string that uniquely identifies a data point's
+// attribute set, so that two points for the same metric sharing one can
+// be merged together instead of sent as duplicate, same-timestamp
+// samples. attrs is assumed to already be in the canonical (sorted by
+// key) order that attribute.Set.Iter produces, which keyValues preserves.
+func dataPointKey(attrs []*commonpb.KeyValue) string {
+	var b strings.Builder
+	for _, kv := range attrs {
+		b.WriteString(kv.Key)
+		b.WriteByte('=')
+		b.WriteString(kv.Value.String())
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// mergeDataPoints merges the data points of src into dst, which must
+// hold the same metric name and data type. Points that share an
+// attribute set with an existing point in dst are combined into it
+// rather than appended, so that OTLP's "one point per unique attribute
+// set" invariant holds even when many Records collapse onto the same
+// label set - most commonly because the cardinality limiter in
+// cardinality.go substituted the same overflow label for all of them.
+func mergeDataPoints(dst, src *metricpb.Metric) error {
+	switch d := dst.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		s, ok := src.Data.(*metricpb.Metric_Gauge)
+		if !ok {
+			return fmt.Errorf("%w: mismatched data types for metric %q", ErrTransforming, dst.Name)
+		}
+		d.Gauge.DataPoints = mergeNumberPoints(d.Gauge.DataPoints, s.Gauge.DataPoints, mergeGaugeValue)
+	case *metricpb.Metric_Sum:
+		s, ok := src.Data.(*metricpb.Metric_Sum)
+		if !ok {
+			return fmt.Errorf("%w: mismatched data types for metric %q", ErrTransforming, dst.Name)
+		}
+		d.Sum.DataPoints = mergeNumberPoints(d.Sum.DataPoints, s.Sum.DataPoints, mergeSumValue)
+	case *metricpb.Metric_Histogram:
+		s, ok := src.Data.(*metricpb.Metric_Histogram)
+		if !ok {
+			return fmt.Errorf("%w: mismatched data types for metric %q", ErrTransforming, dst.Name)
+		}
+		d.Histogram.DataPoints = mergeHistogramPoints(d.Histogram.DataPoints, s.Histogram.DataPoints)
+	case *metricpb.Metric_ExponentialHistogram:
+		s, ok := src.Data.(*metricpb.Metric_ExponentialHistogram)
+		if !ok {
+			return fmt.Errorf("%w: mismatched data types for metric %q", ErrTransforming, dst.Name)
+		}
+		d.ExponentialHistogram.DataPoints = mergeExponentialHistogramPoints(d.ExponentialHistogram.DataPoints, s.ExponentialHistogram.DataPoints)
+	case *metricpb.Metric_Summary:
+		s, ok := src.Data.(*metricpb.Metric_Summary)
+		if !ok {
+			return fmt.Errorf("%w: mismatched data types for metric %q", ErrTransforming, dst.Name)
+		}
+		d.Summary.DataPoints = mergeSummaryPoints(d.Summary.DataPoints, s.Summary.DataPoints)
+	default:
+		return fmt.Errorf("%w: unsupported metric type: %T", ErrTransforming, dst.Data)
+	}
+	return nil
+}
+
+// mergeNumberPoints merges src into dst, combining any point that shares
+// an attribute set with an existing dst point via combine rather than
+// appending a duplicate.
+func mergeNumberPoints(dst, src []*metricpb.NumberDataPoint, combine func(dst, src *metricpb.NumberDataPoint)) []*metricpb.NumberDataPoint {
+	byKey := make(map[string]*metricpb.NumberDataPoint, len(dst))
+	for _, dp := range dst {
+		byKey[dataPointKey(dp.Attributes)] = dp
+	}
+	for _, dp := range src {
+		key := dataPointKey(dp.Attributes)
+		if existing, ok := byKey[key]; ok {
+			combine(existing, dp)
+			continue
+		}
+		dst = append(dst, dp)
+		byKey[key] = dp
+	}
+	return dst
+}
+
+// mergeSumValue adds src's value into dst, the behavior appropriate for
+// a Sum: an overflow series accumulates the total of everything folded
+// into it.
+func mergeSumValue(dst, src *metricpb.NumberDataPoint) {
+	switch v := dst.Value.(type) {
+	case *metricpb.NumberDataPoint_AsInt:
+		v.AsInt += src.GetAsInt()
+	case *metricpb.NumberDataPoint_AsDouble:
+		v.AsDouble += src.GetAsDouble()
+	}
+	if src.TimeUnixNano > dst.TimeUnixNano {
+		dst.TimeUnixNano = src.TimeUnixNano
+	}
+}
+
+// mergeGaugeValue keeps whichever of dst and src was observed most
+// recently: a Gauge reports an instantaneous value, so summing two
+// points folded into the same overflow series would not be meaningful.
+func mergeGaugeValue(dst, src *metricpb.NumberDataPoint) {
+	if src.TimeUnixNano >= dst.TimeUnixNano {
+		dst.Value = src.Value
+		dst.StartTimeUnixNano = src.StartTimeUnixNano
+		dst.TimeUnixNano = src.TimeUnixNano
+	}
+}
+
+// mergeHistogramPoints merges src into dst, summing the count, sum, and
+// per-bucket counts of any point that shares an attribute set with an
+// existing dst point. Per-bucket counts are summed only when both points
+// have the same number of buckets; otherwise the dst point keeps its own
+// bucket counts while its count and sum still include src.
+func mergeHistogramPoints(dst, src []*metricpb.HistogramDataPoint) []*metricpb.HistogramDataPoint {
+	byKey := make(map[string]*metricpb.HistogramDataPoint, len(dst))
+	for _, dp := range dst {
+		byKey[dataPointKey(dp.Attributes)] = dp
+	}
+	for _, dp := range src {
+		key := dataPointKey(dp.Attributes)
+		existing, ok := byKey[key]
+		if !ok {
+			dst = append(dst, dp)
+			byKey[key] = dp
+			continue
+		}
+		existing.Count += dp.Count
+		existing.Sum += dp.Sum
+		if len(existing.BucketCounts) == len(dp.BucketCounts) {
+			for i, c := range dp.BucketCounts {
+				existing.BucketCounts[i] += c
 			}
-			rm.InstrumentationLibraryMetrics = append(rm.InstrumentationLibraryMetrics, ilm)
 		}
-		rms = append(rms, rm)
+		if dp.TimeUnixNano > existing.TimeUnixNano {
+			existing.TimeUnixNano = dp.TimeUnixNano
+		}
 	}
+	return dst
+}
 
-	// Report any transform errors.
-	if len(errStrings) > 0 {
-		return rms, fmt.Errorf("%w:\n -%s", ErrTransforming, strings.Join(errStrings, "\n -"))
+// mergeExponentialHistogramPoints merges src into dst the same way
+// mergeHistogramPoints does for fixed-bucket histograms, additionally
+// merging the positive/negative bucket ranges by offset.
+func mergeExponentialHistogramPoints(dst, src []*metricpb.ExponentialHistogramDataPoint) []*metricpb.ExponentialHistogramDataPoint {
+	byKey := make(map[string]*metricpb.ExponentialHistogramDataPoint, len(dst))
+	for _, dp := range dst {
+		byKey[dataPointKey(dp.Attributes)] = dp
 	}
-	return rms, nil
+	for _, dp := range src {
+		key := dataPointKey(dp.Attributes)
+		existing, ok := byKey[key]
+		if !ok {
+			dst = append(dst, dp)
+			byKey[key] = dp
+			continue
+		}
+		if dp.Scale < existing.Scale {
+			// Two points for the same metric name and attribute set
+			// should already share a scale; if they don't, keep the
+			// coarser (lower resolution) one rather than merging
+			// buckets whose boundaries don't line up.
+			existing.Scale = dp.Scale
+		}
+		existing.Count += dp.Count
+		existing.Sum += dp.Sum
+		existing.ZeroCount += dp.ZeroCount
+		existing.Positive = mergeExponentialBuckets(existing.Positive, dp.Positive)
+		existing.Negative = mergeExponentialBuckets(existing.Negative, dp.Negative)
+		if dp.TimeUnixNano > existing.TimeUnixNano {
+			existing.TimeUnixNano = dp.TimeUnixNano
+		}
+	}
+	return dst
+}
+
+// mergeExponentialBuckets combines two sets of exponential histogram
+// buckets, growing the offset range as needed, the same way the
+// exponential Aggregator's Merge adds bucket counts index by index.
+func mergeExponentialBuckets(dst, src *metricpb.ExponentialHistogramDataPoint_Buckets) *metricpb.ExponentialHistogramDataPoint_Buckets {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return src
+	}
+
+	lo := dst.Offset
+	if src.Offset < lo {
+		lo = src.Offset
+	}
+	hi := dst.Offset + int32(len(dst.BucketCounts)) - 1
+	if srcHi := src.Offset + int32(len(src.BucketCounts)) - 1; srcHi > hi {
+		hi = srcHi
+	}
+
+	counts := make([]uint64, hi-lo+1)
+	for i, c := range dst.BucketCounts {
+		counts[dst.Offset+int32(i)-lo] += c
+	}
+	for i, c := range src.BucketCounts {
+		counts[src.Offset+int32(i)-lo] += c
+	}
+	return &metricpb.ExponentialHistogramDataPoint_Buckets{Offset: lo, BucketCounts: counts}
+}
+
+// mergeSummaryPoints merges src into dst, summing the count and sum of
+// any point that shares an attribute set with an existing dst point and
+// widening its min/max quantile values to cover both.
+func mergeSummaryPoints(dst, src []*metricpb.SummaryDataPoint) []*metricpb.SummaryDataPoint {
+	byKey := make(map[string]*metricpb.SummaryDataPoint, len(dst))
+	for _, dp := range dst {
+		byKey[dataPointKey(dp.Attributes)] = dp
+	}
+	for _, dp := range src {
+		key := dataPointKey(dp.Attributes)
+		existing, ok := byKey[key]
+		if !ok {
+			dst = append(dst, dp)
+			byKey[key] = dp
+			continue
+		}
+		existing.Sum += dp.Sum
+		existing.Count += dp.Count
+		for _, q := range dp.QuantileValues {
+			for _, eq := range existing.QuantileValues {
+				if eq.Quantile != q.Quantile {
+					continue
+				}
+				switch q.Quantile {
+				case 0.0:
+					if q.Value < eq.Value {
+						eq.Value = q.Value
+					}
+				case 1.0:
+					if q.Value > eq.Value {
+						eq.Value = q.Value
+					}
+				}
+			}
+		}
+		if dp.TimeUnixNano > existing.TimeUnixNano {
+			existing.TimeUnixNano = dp.TimeUnixNano
+		}
+	}
+	return dst
 }
 
 // Record transforms a Record into an OTLP Metric. An ErrIncompatibleAgg
 // error is returned if the Record Aggregator is not supported.
+//
+// Record applies no label filtering or cardinality limiting; it is
+// equivalent to calling CheckpointSet with no Options. Callers that want
+// those controls should go through CheckpointSet/CheckpointSetStream.
 func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricpb.Metric, error) {
+	return recordWithConfig(exportSelector, r, config{}, nil)
+}
+
+// recordWithConfig is the configuration-aware implementation behind
+// Record. cfg's label allow/deny lists are applied, and limiter (if
+// non-nil) may substitute cfg.OverflowLabel for the Record's labels once
+// its metric name has exceeded cfg.MaxSeriesPerMetric distinct label
+// sets.
+func recordWithConfig(exportSelector export.ExportKindSelector, r export.Record, cfg config, limiter *cardinalityLimiter) (*metricpb.Metric, error) {
+	desc := r.Descriptor()
+	labels := effectiveLabels(r, desc.Name(), cfg, limiter)
+
 	agg := r.Aggregation()
 	switch agg.Kind() {
 	case aggregation.MinMaxSumCountKind:
@@ -256,14 +600,21 @@ func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricp
 		if !ok {
 			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
 		}
-		return minMaxSumCount(r, mmsc)
+		return minMaxSumCount(r, labels, mmsc)
 
 	case aggregation.HistogramKind:
 		h, ok := agg.(aggregation.Histogram)
 		if !ok {
 			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
 		}
-		return histogramPoint(r, exportSelector.ExportKindFor(r.Descriptor(), aggregation.HistogramKind), h)
+		return histogramPoint(r, labels, exportSelector.ExportKindFor(r.Descriptor(), aggregation.HistogramKind), h)
+
+	case aggregation.ExponentialHistogramKind:
+		eh, ok := agg.(aggregation.ExponentialHistogram)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return exponentialHistogramPoint(r, labels, exportSelector.ExportKindFor(r.Descriptor(), aggregation.ExponentialHistogramKind), eh)
 
 	case aggregation.SumKind:
 		s, ok := agg.(aggregation.Sum)
@@ -274,7 +625,7 @@ func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricp
 		if err != nil {
 			return nil, err
 		}
-		return sumPoint(r, sum, r.StartTime(), r.EndTime(), exportSelector.ExportKindFor(r.Descriptor(), aggregation.SumKind), r.Descriptor().InstrumentKind().Monotonic())
+		return sumPoint(r, labels, sum, r.StartTime(), r.EndTime(), exportSelector.ExportKindFor(r.Descriptor(), aggregation.SumKind), r.Descriptor().InstrumentKind().Monotonic())
 
 	case aggregation.LastValueKind:
 		lv, ok := agg.(aggregation.LastValue)
@@ -285,7 +636,7 @@ func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricp
 		if err != nil {
 			return nil, err
 		}
-		return gaugePoint(r, value, time.Time{}, tm)
+		return gaugePoint(r, labels, value, time.Time{}, tm)
 
 	case aggregation.ExactKind:
 		e, ok := agg.(aggregation.Points)
@@ -297,16 +648,15 @@ func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricp
 			return nil, err
 		}
 
-		return gaugeArray(r, pts)
+		return gaugeArray(r, labels, pts)
 
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnimplementedAgg, agg)
 	}
 }
 
-func gaugeArray(record export.Record, points []aggregation.Point) (*metricpb.Metric, error) {
+func gaugeArray(record export.Record, labels *attribute.Set, points []aggregation.Point) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
-	labels := record.Labels()
 	m := &metricpb.Metric{
 		Name:        desc.Name(),
 		Description: desc.Description(),
@@ -351,9 +701,8 @@ func gaugeArray(record export.Record, points []aggregation.Point) (*metricpb.Met
 	return m, nil
 }
 
-func gaugePoint(record export.Record, num number.Number, start, end time.Time) (*metricpb.Metric, error) {
+func gaugePoint(record export.Record, labels *attribute.Set, num number.Number, start, end time.Time) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
-	labels := record.Labels()
 
 	m := &metricpb.Metric{
 		Name:        desc.Name(),
@@ -409,9 +758,8 @@ func exportKindToTemporality(ek export.ExportKind) metricpb.AggregationTemporali
 	return metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
 }
 
-func sumPoint(record export.Record, num number.Number, start, end time.Time, ek export.ExportKind, monotonic bool) (*metricpb.Metric, error) {
+func sumPoint(record export.Record, labels *attribute.Set, num number.Number, start, end time.Time, ek export.ExportKind, monotonic bool) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
-	labels := record.Labels()
 
 	m := &metricpb.Metric{
 		Name:        desc.Name(),
@@ -480,9 +828,8 @@ func minMaxSumCountValues(a aggregation.MinMaxSumCount) (min, max, sum number.Nu
 }
 
 // minMaxSumCount transforms a MinMaxSumCount Aggregator into an OTLP Metric.
-func minMaxSumCount(record export.Record, a aggregation.MinMaxSumCount) (*metricpb.Metric, error) {
+func minMaxSumCount(record export.Record, labels *attribute.Set, a aggregation.MinMaxSumCount) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
-	labels := record.Labels()
 	min, max, sum, count, err := minMaxSumCountValues(a)
 	if err != nil {
 		return nil, err
@@ -533,9 +880,8 @@ func histogramValues(a aggregation.Histogram) (boundaries []float64, counts []ui
 }
 
 // histogram transforms a Histogram Aggregator into an OTLP Metric.
-func histogramPoint(record export.Record, ek export.ExportKind, a aggregation.Histogram) (*metricpb.Metric, error) {
+func histogramPoint(record export.Record, labels *attribute.Set, ek export.ExportKind, a aggregation.Histogram) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
-	labels := record.Labels()
 	boundaries, counts, err := histogramValues(a)
 	if err != nil {
 		return nil, err
@@ -575,6 +921,74 @@ func histogramPoint(record export.Record, ek export.ExportKind, a aggregation.Hi
 	return m, nil
 }
 
+// exponentialBucketsToPB transforms the bucket counts of an
+// aggregation.ExponentialBuckets into the repeated OTLP Buckets message.
+func exponentialBucketsToPB(b aggregation.ExponentialBuckets) *metricpb.ExponentialHistogramDataPoint_Buckets {
+	if len(b.Counts) == 0 {
+		return nil
+	}
+	return &metricpb.ExponentialHistogramDataPoint_Buckets{
+		Offset:       b.Offset,
+		BucketCounts: b.Counts,
+	}
+}
+
+// exponentialHistogramPoint transforms an ExponentialHistogram Aggregator
+// into an OTLP Metric.
+func exponentialHistogramPoint(record export.Record, labels *attribute.Set, ek export.ExportKind, a aggregation.ExponentialHistogram) (*metricpb.Metric, error) {
+	desc := record.Descriptor()
+
+	scale, err := a.Scale()
+	if err != nil {
+		return nil, err
+	}
+	zeroCount, err := a.ZeroCount()
+	if err != nil {
+		return nil, err
+	}
+	positive, err := a.Positive()
+	if err != nil {
+		return nil, err
+	}
+	negative, err := a.Negative()
+	if err != nil {
+		return nil, err
+	}
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &metricpb.Metric{
+		Name:        desc.Name(),
+		Description: desc.Description(),
+		Unit:        string(desc.Unit()),
+		Data: &metricpb.Metric_ExponentialHistogram{
+			ExponentialHistogram: &metricpb.ExponentialHistogram{
+				AggregationTemporality: exportKindToTemporality(ek),
+				DataPoints: []*metricpb.ExponentialHistogramDataPoint{
+					{
+						Sum:               sum.CoerceToFloat64(desc.NumberKind()),
+						Attributes:        keyValues(labels.Iter()),
+						StartTimeUnixNano: toNanos(record.StartTime()),
+						TimeUnixNano:      toNanos(record.EndTime()),
+						Count:             count,
+						Scale:             scale,
+						ZeroCount:         zeroCount,
+						Positive:          exponentialBucketsToPB(positive),
+						Negative:          exponentialBucketsToPB(negative),
+					},
+				},
+			},
+		},
+	}
+	return m, nil
+}
+
 // keyValues transforms an attribute iterator into an OTLP KeyValues.
 func keyValues(iter attribute.Iterator) []*commonpb.KeyValue {
 	l := iter.Len()
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// cumulativeSelector is the simplest possible export.ExportKindSelector,
+// used by tests that don't care which ExportKind a Descriptor maps to.
+type cumulativeSelector struct{}
+
+func (cumulativeSelector) ExportKindFor(*export.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.CumulativeExportKind
+}
+
+// TestExponentialHistogramRoundTrip checks that an ExponentialHistogram
+// aggregation survives Record's translation into an OTLP Metric: scale,
+// zero count, sum, count, and the positive/negative bucket offsets and
+// counts must all come through unchanged.
+func TestExponentialHistogramRoundTrip(t *testing.T) {
+	aggs := exponential.New(1, 4)
+	agg := &aggs[0]
+	desc := export.NewDescriptor("test.histogram", 0, number.Float64Kind)
+
+	ctx := context.Background()
+	for _, v := range []float64{1, 2, 2, -1, 0} {
+		require.NoError(t, agg.Update(ctx, number.NewFloat64Number(v), desc))
+	}
+
+	wantScale, err := agg.Scale()
+	require.NoError(t, err)
+	wantZero, err := agg.ZeroCount()
+	require.NoError(t, err)
+	wantCount, err := agg.Count()
+	require.NoError(t, err)
+	wantSum, err := agg.Sum()
+	require.NoError(t, err)
+	wantPositive, err := agg.Positive()
+	require.NoError(t, err)
+	wantNegative, err := agg.Negative()
+	require.NoError(t, err)
+
+	labels := attribute.NewSet(attribute.String("env", "prod"))
+	start := time.Unix(0, 1000)
+	end := time.Unix(0, 2000)
+	record := export.NewRecord(desc, &labels, resource.Empty(), agg.Aggregation(), start, end)
+
+	m, err := Record(cumulativeSelector{}, record)
+	require.NoError(t, err)
+	require.Equal(t, "test.histogram", m.Name)
+
+	eh, ok := m.Data.(*metricpb.Metric_ExponentialHistogram)
+	require.True(t, ok, "expected ExponentialHistogram data, got %T", m.Data)
+	require.Equal(t, metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, eh.ExponentialHistogram.AggregationTemporality)
+	require.Len(t, eh.ExponentialHistogram.DataPoints, 1)
+
+	dp := eh.ExponentialHistogram.DataPoints[0]
+	require.Equal(t, wantScale, dp.Scale)
+	require.Equal(t, wantZero, dp.ZeroCount)
+	require.Equal(t, wantCount, dp.Count)
+	require.Equal(t, wantSum.CoerceToFloat64(number.Float64Kind), dp.Sum)
+	require.Equal(t, uint64(1000), dp.StartTimeUnixNano)
+	require.Equal(t, uint64(2000), dp.TimeUnixNano)
+
+	require.NotNil(t, dp.Positive)
+	require.Equal(t, wantPositive.Offset, dp.Positive.Offset)
+	require.Equal(t, wantPositive.Counts, dp.Positive.BucketCounts)
+
+	require.NotNil(t, dp.Negative)
+	require.Equal(t, wantNegative.Offset, dp.Negative.Offset)
+	require.Equal(t, wantNegative.Counts, dp.Negative.BucketCounts)
+
+	require.Len(t, dp.Attributes, 1)
+	require.Equal(t, "env", dp.Attributes[0].Key)
+}
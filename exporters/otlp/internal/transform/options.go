@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform // import "go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+
+import "go.opentelemetry.io/otel/attribute"
+
+// defaultOverflowLabel tags the single series that absorbs data points
+// once a metric exceeds its configured cardinality limit.
+var defaultOverflowLabel = attribute.Bool("otel_overflow", true)
+
+// config contains the options for CheckpointSet and CheckpointSetStream.
+type config struct {
+	// MaxPointsPerBatch is the number of data points a Resource's
+	// accumulated ResourceMetrics may hold before it is flushed to the
+	// output chan. Zero (the default) disables batching by point count,
+	// so a Resource's ResourceMetrics is only sent once the checkpoint is
+	// fully drained.
+	MaxPointsPerBatch int
+
+	// MaxSeriesPerMetric caps the number of distinct label sets a single
+	// metric name may produce within one checkpoint. Zero (the default)
+	// disables the limit.
+	MaxSeriesPerMetric int
+
+	// LabelAllowList, when set for a metric name, restricts that metric's
+	// exported labels to the listed keys.
+	LabelAllowList map[string][]string
+
+	// LabelDenyList is dropped from every Record's labels before export.
+	LabelDenyList []attribute.Key
+
+	// OverflowLabel replaces a Record's labels once its metric name has
+	// exceeded MaxSeriesPerMetric. The default is otel_overflow=true.
+	OverflowLabel attribute.KeyValue
+
+	// ForceCumulative, when non-nil, rewrites delta Sums into cumulative
+	// Sums using the given DeltaToCumulative stage before they reach the
+	// sink.
+	ForceCumulative *DeltaToCumulative
+}
+
+// Option applies a configuration option to CheckpointSet or
+// CheckpointSetStream.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(cfg *config) {
+	f(cfg)
+}
+
+// WithMaxPointsPerBatch sets the number of data points a Resource's
+// ResourceMetrics may accumulate before it is flushed, letting callers
+// bound peak memory usage when transforming checkpoints with very large
+// numbers of series. The default, zero, disables this and batches every
+// Resource into a single ResourceMetrics.
+func WithMaxPointsPerBatch(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.MaxPointsPerBatch = n
+	})
+}
+
+// WithMaxSeriesPerMetric sets the number of distinct label sets a single
+// metric name may produce within one checkpoint before further label
+// sets are merged into a single overflow series (see WithOverflowLabel).
+// The default, zero, disables the limit.
+func WithMaxSeriesPerMetric(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.MaxSeriesPerMetric = n
+	})
+}
+
+// WithLabelAllowList restricts, per metric name, the set of label keys
+// that are exported. Metric names absent from allowList are unaffected.
+func WithLabelAllowList(allowList map[string][]string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.LabelAllowList = allowList
+	})
+}
+
+// WithLabelDenyList drops the given label keys from every Record before
+// it is exported.
+func WithLabelDenyList(denyList []attribute.Key) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.LabelDenyList = denyList
+	})
+}
+
+// WithOverflowLabel sets the label attached to the single series that
+// absorbs data points once a metric exceeds WithMaxSeriesPerMetric. The
+// default is otel_overflow=true.
+func WithOverflowLabel(overflow attribute.KeyValue) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.OverflowLabel = overflow
+	})
+}
+
+// WithForceCumulative rewrites delta Sums into cumulative Sums using d,
+// for backends (such as Prometheus-style pull systems) that reject delta
+// counters. d accumulates running totals per series, so callers should
+// construct it once with NewDeltaToCumulative and reuse it across
+// repeated CheckpointSet/CheckpointSetStream calls rather than
+// recreating it every export cycle.
+func WithForceCumulative(d *DeltaToCumulative) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.ForceCumulative = d
+	})
+}
+
+func newConfig(opts ...Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// testSum is a minimal aggregation.Sum used to build Records for the
+// streaming/batching tests below, without depending on a real Aggregator
+// implementation.
+type testSum struct{ sum number.Number }
+
+func (testSum) Kind() aggregation.Kind        { return aggregation.SumKind }
+func (s testSum) Sum() (number.Number, error) { return s.sum, nil }
+
+func newSumRecord(name string, v int64, res *resource.Resource) export.Record {
+	desc := export.NewDescriptor(name, 0, number.Int64Kind)
+	labels := attribute.NewSet()
+	return export.NewRecord(desc, &labels, res, testSum{number.NewInt64Number(v)}, time.Time{}, time.Time{})
+}
+
+func mustSumMetric(t *testing.T, name string, v int64, res *resource.Resource) *metricpb.Metric {
+	t.Helper()
+	m, err := recordWithConfig(cumulativeSelector{}, newSumRecord(name, v, res), config{}, nil)
+	require.NoError(t, err)
+	return m
+}
+
+// TestSinkFlushesOnceMaxPointsPerBatchIsCrossed checks that sink emits a
+// ResourceMetrics as soon as the accumulated data-point count for a
+// Resource reaches cfg.MaxPointsPerBatch, and flushes whatever remains
+// once the input is drained.
+func TestSinkFlushesOnceMaxPointsPerBatchIsCrossed(t *testing.T) {
+	ctx := context.Background()
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	in := make(chan result)
+	out := make(chan *metricpb.ResourceMetrics)
+	cfg := config{MaxPointsPerBatch: 2}
+
+	var got []*metricpb.ResourceMetrics
+	recvDone := make(chan struct{})
+	go func() {
+		for rm := range out {
+			got = append(got, rm)
+		}
+		close(recvDone)
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- sink(ctx, in, cfg, out) }()
+
+	in <- result{Resource: res, Metric: mustSumMetric(t, "a", 1, res)}
+	in <- result{Resource: res, Metric: mustSumMetric(t, "b", 2, res)}
+	in <- result{Resource: res, Metric: mustSumMetric(t, "c", 3, res)}
+	close(in)
+
+	require.NoError(t, <-done)
+	close(out)
+	<-recvDone
+
+	require.Len(t, got, 2, "expected one flush at the MaxPointsPerBatch threshold and one at close")
+	require.Len(t, got[0].InstrumentationLibraryMetrics[0].Metrics, 2)
+	require.Len(t, got[1].InstrumentationLibraryMetrics[0].Metrics, 1)
+}
+
+// TestSinkFlushesOnlyAtCloseWhenNoBatchLimit checks that, with
+// MaxPointsPerBatch unset, sink holds everything in memory and emits a
+// single ResourceMetrics once the input is drained.
+func TestSinkFlushesOnlyAtCloseWhenNoBatchLimit(t *testing.T) {
+	ctx := context.Background()
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	in := make(chan result)
+	out := make(chan *metricpb.ResourceMetrics, 1)
+	cfg := config{}
+
+	go func() {
+		in <- result{Resource: res, Metric: mustSumMetric(t, "a", 1, res)}
+		in <- result{Resource: res, Metric: mustSumMetric(t, "b", 2, res)}
+		close(in)
+	}()
+
+	require.NoError(t, sink(ctx, in, cfg, out))
+	close(out)
+
+	var got []*metricpb.ResourceMetrics
+	for rm := range out {
+		got = append(got, rm)
+	}
+	require.Len(t, got, 1)
+	require.Len(t, got[0].InstrumentationLibraryMetrics[0].Metrics, 2)
+}
+
+// TestCheckpointSetStreamsAcrossWorkers exercises the full
+// CheckpointSetStream pipeline - source, transformer workers, sink - and
+// checks that every Record in the CheckpointSet is represented in the
+// streamed ResourceMetrics.
+func TestCheckpointSetStreamsAcrossWorkers(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	cps := fakeCheckpointSet{records: []export.Record{
+		newSumRecord("a", 1, res),
+		newSumRecord("b", 2, res),
+		newSumRecord("c", 3, res),
+	}}
+
+	rms, err := CheckpointSet(context.Background(), cumulativeSelector{}, cps, 2)
+	require.NoError(t, err)
+	require.Len(t, rms, 1)
+
+	var names []string
+	for _, m := range rms[0].InstrumentationLibraryMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	require.ElementsMatch(t, []string{"a", "b", "c"}, names)
+}
+
+// fakeCheckpointSet implements export.CheckpointSet over a fixed slice
+// of Records, for tests that don't need a real SDK checkpoint.
+type fakeCheckpointSet struct{ records []export.Record }
+
+func (f fakeCheckpointSet) ForEach(_ export.ExportKindSelector, fn func(export.Record) error) error {
+	for _, r := range f.records {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation // import "go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+
+import "go.opentelemetry.io/otel/metric/number"
+
+// ExponentialHistogramKind indicates an aggregation.ExponentialHistogram
+// Aggregation.
+const ExponentialHistogramKind Kind = "ExponentialHistogram"
+
+// ExponentialBuckets is the count of events in contiguous buckets of a
+// base-2 exponential histogram. Offset is the index of the first bucket,
+// Counts is the observation count of each bucket starting at Offset. The
+// boundaries of bucket i are (base^i, base^(i+1)], where base =
+// 2^(2^-scale).
+type ExponentialBuckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+// ExponentialHistogram returns the Sum, Count, Scale, ZeroCount, and the
+// positive and negative ExponentialBuckets of an exponential histogram
+// Aggregation.
+type ExponentialHistogram interface {
+	Aggregation
+
+	// Sum returns the sum of values observed.
+	Sum() (number.Number, error)
+
+	// Count returns the number of values observed.
+	Count() (uint64, error)
+
+	// Scale returns the current resolution scale of the histogram. Base is
+	// 2^(2^-scale).
+	Scale() (int32, error)
+
+	// ZeroCount returns the number of observed values that mapped to the
+	// zero bucket.
+	ZeroCount() (uint64, error)
+
+	// Positive returns the bucket counts for observed values greater than
+	// zero.
+	Positive() (ExponentialBuckets, error)
+
+	// Negative returns the bucket counts for the absolute value of
+	// observed values less than zero.
+	Negative() (ExponentialBuckets, error)
+}
@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exponential provides a base-2 exponential histogram Aggregator,
+// as described by the OpenTelemetry specification for exponential bucket
+// histograms.
+package exponential // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// DefaultMaxSize is the default number of buckets kept on each of the
+// positive and negative ranges before the histogram is downscaled.
+const DefaultMaxSize = 160
+
+// ErrInvalidMaxSize is returned when a non-positive max bucket size is
+// configured.
+var ErrInvalidMaxSize = errors.New("exponential histogram: max size must be positive")
+
+// buckets is a contiguous, dense set of bucket counts together with the
+// index of the first bucket. Index i (relative to Offset) holds the count
+// of values falling in (base^(i+Offset), base^(i+Offset+1)], where base =
+// 2^(2^-scale).
+type buckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+func (b *buckets) at(i int32) uint64 {
+	idx := i - b.Offset
+	if idx < 0 || int(idx) >= len(b.Counts) {
+		return 0
+	}
+	return b.Counts[idx]
+}
+
+// grow extends the backing slice so that index i is addressable, moving
+// Offset down to i when i lies below the current range (or when b is
+// empty).
+func (b *buckets) grow(i int32) {
+	if len(b.Counts) == 0 {
+		b.Offset = i
+		b.Counts = []uint64{0}
+		return
+	}
+	if i < b.Offset {
+		lead := make([]uint64, b.Offset-i)
+		b.Counts = append(lead, b.Counts...)
+		b.Offset = i
+		return
+	}
+	if last := b.Offset + int32(len(b.Counts)) - 1; i > last {
+		b.Counts = append(b.Counts, make([]uint64, i-last)...)
+	}
+}
+
+// incr records one observation in the bucket for index i.
+func (b *buckets) incr(i int32) {
+	b.grow(i)
+	b.Counts[i-b.Offset]++
+}
+
+// downscale halves the resolution of the histogram by merging each pair
+// of adjacent buckets. The caller's scale is assumed to already have been
+// decremented by one.
+func (b *buckets) downscale() {
+	if len(b.Counts) == 0 {
+		return
+	}
+	// floorDiv2 maps the old index to the new, coarser index.
+	newOffset := floorDiv2(b.Offset)
+	newLast := floorDiv2(b.Offset + int32(len(b.Counts)) - 1)
+	merged := make([]uint64, newLast-newOffset+1)
+	for i, c := range b.Counts {
+		if c == 0 {
+			continue
+		}
+		idx := floorDiv2(b.Offset+int32(i)) - newOffset
+		merged[idx] += c
+	}
+	b.Offset = newOffset
+	b.Counts = merged
+}
+
+func floorDiv2(i int32) int32 {
+	if i >= 0 {
+		return i / 2
+	}
+	return -((-i + 1) / 2)
+}
+
+// Aggregator aggregates measurements into a base-2 exponential histogram,
+// downscaling automatically to stay within MaxSize buckets per range.
+type Aggregator struct {
+	lock sync.Mutex
+
+	maxSize int32
+	scale   int32
+
+	sum       number.Number
+	count     uint64
+	zeroCount uint64
+	positive  buckets
+	negative  buckets
+}
+
+var _ export.Aggregator = &Aggregator{}
+var _ aggregation.ExponentialHistogram = &Aggregator{}
+
+// New returns n new exponential histogram Aggregators configured with
+// maxSize buckets on each of the positive and negative ranges. If maxSize
+// is not positive, DefaultMaxSize is used.
+func New(n int, maxSize int32) []Aggregator {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	aggs := make([]Aggregator, n)
+	for i := range aggs {
+		aggs[i] = Aggregator{maxSize: maxSize, scale: maxScale}
+	}
+	return aggs
+}
+
+// maxScale is the finest resolution supported before any downscaling is
+// necessary; it is chosen so a freshly observed value always maps to a
+// representable index.
+const maxScale = 20
+
+// Kind returns aggregation.ExponentialHistogramKind.
+func (a *Aggregator) Kind() aggregation.Kind {
+	return aggregation.ExponentialHistogramKind
+}
+
+// Aggregation returns an interface for reading the state of this
+// Aggregator.
+func (a *Aggregator) Aggregation() aggregation.Aggregation {
+	return a
+}
+
+// Sum returns the sum of the values observed.
+func (a *Aggregator) Sum() (number.Number, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.sum, nil
+}
+
+// Count returns the number of values observed.
+func (a *Aggregator) Count() (uint64, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.count, nil
+}
+
+// Scale returns the current resolution scale.
+func (a *Aggregator) Scale() (int32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.scale, nil
+}
+
+// ZeroCount returns the number of values that mapped to the zero bucket.
+func (a *Aggregator) ZeroCount() (uint64, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.zeroCount, nil
+}
+
+// Positive returns the bucket counts for observed values greater than
+// zero.
+func (a *Aggregator) Positive() (aggregation.ExponentialBuckets, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return copyBuckets(a.positive), nil
+}
+
+// Negative returns the bucket counts for the absolute value of observed
+// values less than zero.
+func (a *Aggregator) Negative() (aggregation.ExponentialBuckets, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return copyBuckets(a.negative), nil
+}
+
+func copyBuckets(b buckets) aggregation.ExponentialBuckets {
+	counts := make([]uint64, len(b.Counts))
+	copy(counts, b.Counts)
+	return aggregation.ExponentialBuckets{Offset: b.Offset, Counts: counts}
+}
+
+// Update records a new observation.
+func (a *Aggregator) Update(_ context.Context, num number.Number, desc *export.Descriptor) error {
+	v := num.CoerceToFloat64(desc.NumberKind())
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.count++
+	a.sum.AddNumber(desc.NumberKind(), num)
+
+	if v == 0 {
+		a.zeroCount++
+		return nil
+	}
+
+	abs := math.Abs(v)
+	side := &a.positive
+	if v < 0 {
+		side = &a.negative
+	}
+
+	// Downscale until idx fits within maxSize buckets, and only then
+	// increment its bucket, so the observation is counted exactly once.
+	idx := index(abs, a.scale)
+	for !side.fits(idx, a.maxSize) {
+		a.downscale()
+		idx = index(abs, a.scale)
+	}
+	side.incr(idx)
+	return nil
+}
+
+// fits reports whether incrementing bucket i would keep the contiguous
+// range of buckets within maxSize, without mutating b.
+func (b *buckets) fits(i int32, maxSize int32) bool {
+	if len(b.Counts) == 0 {
+		return true
+	}
+	lo, hi := b.Offset, b.Offset+int32(len(b.Counts))-1
+	if i < lo {
+		lo = i
+	}
+	if i > hi {
+		hi = i
+	}
+	return hi-lo+1 <= maxSize
+}
+
+// rangeOf returns the number of contiguous buckets currently in use, used
+// to decide whether a downscale is needed.
+func (a *Aggregator) rangeOf(side *buckets) int32 {
+	if len(side.Counts) == 0 {
+		return 0
+	}
+	return int32(len(side.Counts))
+}
+
+// downscale halves the scale and merges adjacent buckets on both ranges.
+func (a *Aggregator) downscale() {
+	a.scale--
+	a.positive.downscale()
+	a.negative.downscale()
+}
+
+// index returns the bucket index for the absolute value v at the given
+// scale. Buckets are (base^i, base^(i+1)], base = 2^(2^-scale), so the
+// index is ceil(log2(v) * 2^scale) - 1: a value exactly on a bucket
+// boundary belongs to the lower bucket.
+func index(v float64, scale int32) int32 {
+	return int32(math.Ceil(math.Log2(v)*math.Ldexp(1, int(scale)))) - 1
+}
+
+// SynchronizedMove saves the current state into the destination
+// Aggregator and resets this Aggregator for the next collection period.
+func (a *Aggregator) SynchronizedMove(dest export.Aggregator, desc *export.Descriptor) error {
+	d, _ := dest.(*Aggregator)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if d != nil {
+		d.lock.Lock()
+		d.maxSize = a.maxSize
+		d.scale = a.scale
+		d.sum = a.sum
+		d.count = a.count
+		d.zeroCount = a.zeroCount
+		d.positive = buckets{Offset: a.positive.Offset, Counts: append([]uint64(nil), a.positive.Counts...)}
+		d.negative = buckets{Offset: a.negative.Offset, Counts: append([]uint64(nil), a.negative.Counts...)}
+		d.lock.Unlock()
+	}
+
+	a.sum = number.Number(0)
+	a.count = 0
+	a.zeroCount = 0
+	a.positive = buckets{}
+	a.negative = buckets{}
+	a.scale = maxScale
+	return nil
+}
+
+// Merge combines the checkpointed state of o into a, downscaling as
+// needed so both operands share a single resolution.
+func (a *Aggregator) Merge(o export.Aggregator, desc *export.Descriptor) error {
+	other, ok := o.(*Aggregator)
+	if !ok {
+		return aggregation.ErrInconsistentType
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	other.lock.Lock()
+	defer other.lock.Unlock()
+
+	if other.scale < a.scale {
+		for a.scale > other.scale {
+			a.downscale()
+		}
+	}
+	for other.scale > a.scale {
+		other.downscale()
+	}
+
+	a.sum.AddNumber(desc.NumberKind(), other.sum)
+	a.count += other.count
+	a.zeroCount += other.zeroCount
+	mergeBuckets(&a.positive, &other.positive)
+	mergeBuckets(&a.negative, &other.negative)
+
+	for a.rangeOf(&a.positive) > a.maxSize || a.rangeOf(&a.negative) > a.maxSize {
+		a.downscale()
+	}
+	return nil
+}
+
+func mergeBuckets(dst, src *buckets) {
+	for i, c := range src.Counts {
+		if c == 0 {
+			continue
+		}
+		idx := src.Offset + int32(i)
+		dst.grow(idx)
+		dst.Counts[idx-dst.Offset] += c
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+func sumCounts(t *testing.T, a *Aggregator) uint64 {
+	t.Helper()
+	var total uint64
+	pos, err := a.Positive()
+	require.NoError(t, err)
+	for _, c := range pos.Counts {
+		total += c
+	}
+	neg, err := a.Negative()
+	require.NoError(t, err)
+	for _, c := range neg.Counts {
+		total += c
+	}
+	zero, err := a.ZeroCount()
+	require.NoError(t, err)
+	total += zero
+	return total
+}
+
+// TestUpdateCountInvariant guards the Count()==sum(bucket counts)+ZeroCount
+// invariant across updates that force a downscale, where each observation
+// must be counted exactly once regardless of how many scale levels are
+// tried before it fits.
+func TestUpdateCountInvariant(t *testing.T) {
+	aggs := New(1, 4)
+	a := &aggs[0]
+	desc := export.NewDescriptor("test", 0, number.Float64Kind)
+
+	for _, v := range []float64{1.0, 2.0, 4.0, 8.0, 16.0} {
+		require.NoError(t, a.Update(context.Background(), number.NewFloat64Number(v), desc))
+	}
+
+	count, err := a.Count()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, count)
+	require.EqualValues(t, count, sumCounts(t, a))
+}
+
+// TestIndexBoundary checks that a value exactly on a bucket boundary maps
+// to the lower bucket, per the (base^i, base^(i+1)] definition.
+func TestIndexBoundary(t *testing.T) {
+	require.EqualValues(t, 0, index(2.0, 0))
+	require.EqualValues(t, -1, index(1.0, 0))
+	require.EqualValues(t, 0, index(1.5, 0))
+}